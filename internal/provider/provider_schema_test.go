@@ -1,314 +1,146 @@
 package provider
 
 import (
-	"fmt"
-	"sort"
-	"strings"
+	"path/filepath"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-
-	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider/schemalint"
 )
 
-func TestDataSourcesHaveSensitiveFieldsMarkedAsSensitive(t *testing.T) {
-	provider := TestAzureProvider()
-
-	// intentionally sorting these so the output is consistent
-	dataSourceNames := make([]string, 0)
-	for dataSourceName := range provider.DataSourcesMap {
-		dataSourceNames = append(dataSourceNames, dataSourceName)
-	}
-	sort.Strings(dataSourceNames)
+const schemaLintWaiversPath = "schemalint/waivers.json"
 
-	for _, dataSourceName := range dataSourceNames {
-		dataSource := provider.DataSourcesMap[dataSourceName]
-		if err := schemaContainsSensitiveFieldsNotMarkedAsSensitive(dataSource.Schema); err != nil {
-			t.Fatalf("the Data Source %q contains a sensitive field which isn't marked as sensitive: %+v", dataSourceName, err)
-		}
-	}
+func TestDataSourcesHaveSensitiveFieldsMarkedAsSensitive(t *testing.T) {
+	lintDataSources(t, schemalint.NewSensitiveFieldsRule())
 }
 
 func TestResourcesHaveSensitiveFieldsMarkedAsSensitive(t *testing.T) {
-	provider := TestAzureProvider()
-
-	// intentionally sorting these so the output is consistent
-	resourceNames := make([]string, 0)
-	for resourceName := range provider.ResourcesMap {
-		resourceNames = append(resourceNames, resourceName)
-	}
-	sort.Strings(resourceNames)
-
-	for _, resourceName := range resourceNames {
-		resource := provider.ResourcesMap[resourceName]
-		if err := schemaContainsSensitiveFieldsNotMarkedAsSensitive(resource.Schema); err != nil {
-			t.Fatalf("the Resource %q contains a sensitive field which isn't marked as sensitive: %+v", resourceName, err)
-		}
-	}
+	lintResources(t, schemalint.NewSensitiveFieldsRule())
 }
 
-func schemaContainsSensitiveFieldsNotMarkedAsSensitive(input map[string]*pluginsdk.Schema) error {
-	exactMatchFieldNames := []string{
-		"api_key",
-		"api_secret_key",
-		"password",
-		"private_key",
-		"ssh_private_key",
-	}
-
-	// intentionally sorting these so the output is consistent
-	fieldNames := make([]string, 0)
-	for fieldName := range input {
-		fieldNames = append(fieldNames, fieldName)
-	}
-	sort.Strings(fieldNames)
-
-	for _, fieldName := range fieldNames {
-		key := strings.ToLower(fieldName)
-		field := input[fieldName]
+func TestDataSourcesDoNotHaveTyposInSensitiveFieldNames(t *testing.T) {
+	lintDataSources(t, schemalint.NewSensitiveFieldTypoRule())
+}
 
-		for _, val := range exactMatchFieldNames {
-			if strings.EqualFold(key, val) && !field.Sensitive {
-				return fmt.Errorf("field %q is a sensitive value and should be marked as Sensitive", fieldName)
-			}
-		}
+func TestResourcesDoNotHaveTyposInSensitiveFieldNames(t *testing.T) {
+	lintResources(t, schemalint.NewSensitiveFieldTypoRule())
+}
 
-		if strings.HasSuffix(key, "_api_key") && field.Type == pluginsdk.TypeString && !field.Sensitive {
-			return fmt.Errorf("field %q is a sensitive value and should be marked as Sensitive", fieldName)
-		}
+func TestDataSourcesDoNotLeakSensitiveValuesViaComputedFields(t *testing.T) {
+	lintDataSources(t, schemalint.NewSensitivityPropagationRule())
+}
 
-		if field.Type == pluginsdk.TypeList && field.Elem != nil {
-			if val, ok := field.Elem.(*pluginsdk.Resource); ok && val.Schema != nil {
-				if err := schemaContainsSensitiveFieldsNotMarkedAsSensitive(val.Schema); err != nil {
-					return fmt.Errorf("the field %q is a List: %+v", fieldName, err)
-				}
-			}
-		}
+func TestResourcesDoNotLeakSensitiveValuesViaComputedFields(t *testing.T) {
+	lintResources(t, schemalint.NewSensitivityPropagationRule())
+}
 
-		if field.Type == pluginsdk.TypeSet && field.Elem != nil {
-			if val, ok := field.Elem.(*pluginsdk.Resource); ok && val.Schema != nil {
-				if err := schemaContainsSensitiveFieldsNotMarkedAsSensitive(val.Schema); err != nil {
-					return fmt.Errorf("the field %q is a Set: %+v", fieldName, err)
-				}
-			}
-		}
+// TestReadFunctionsDoNotWriteSensitiveValuesToNonSensitiveFields is a small
+// AST pass over internal/services/** which warns when a `d.Set(...)` call
+// writes a value that looks like it was derived from a secret into an
+// attribute whose name doesn't itself look sensitive - e.g. a rotated
+// connection string leaking into an `endpoint` field. The attribute's schema
+// Sensitive flag is not consulted.
+func TestReadFunctionsDoNotWriteSensitiveValuesToNonSensitiveFields(t *testing.T) {
+	findings, err := schemalint.CheckReadFunctionLeaks("../services")
+	if err != nil {
+		t.Fatalf("checking Read functions for sensitive leaks: %+v", err)
 	}
 
-	return nil
+	waivers := loadSchemaLintWaivers(t)
+	remaining, _ := schemalint.ApplyWaivers(findings, waivers)
+	for _, finding := range remaining {
+		// this is a heuristic, not a type-level guarantee - log rather than fail the build
+		t.Logf("[%s] %s: %s", finding.RuleID, finding.Path, finding.Message)
+	}
 }
 
 func TestDataSourcesHaveEnabledFieldsMarkedAsBooleans(t *testing.T) {
-	provider := TestAzureProvider()
-
-	// intentionally sorting these so the output is consistent
-	dataSourceNames := make([]string, 0)
-	for dataSourceName := range provider.DataSourcesMap {
-		dataSourceNames = append(dataSourceNames, dataSourceName)
-	}
-	sort.Strings(dataSourceNames)
-
-	for _, dataSourceName := range dataSourceNames {
-		dataSource := provider.DataSourcesMap[dataSourceName]
-		if err := schemaContainsEnabledFieldsNotDefinedAsABoolean(dataSource.Schema, map[string]struct{}{}); err != nil {
-			t.Fatalf("the Data Source %q contains an `_enabled` field which isn't defined as a boolean: %+v", dataSourceName, err)
-		}
-	}
+	lintDataSources(t, schemalint.NewEnabledBooleanRule())
 }
 
 func TestResourcesHaveEnabledFieldsMarkedAsBooleans(t *testing.T) {
-	provider := TestAzureProvider()
+	lintResources(t, schemalint.NewEnabledBooleanRule())
+}
 
-	// intentionally sorting these so the output is consistent
-	resourceNames := make([]string, 0)
-	for resourceName := range provider.ResourcesMap {
-		resourceNames = append(resourceNames, resourceName)
-	}
-	sort.Strings(resourceNames)
+func TestResourcesHaveImportSafeSensitiveAttributes(t *testing.T) {
+	lintResources(t, schemalint.NewGenconfigSensitiveRule())
+}
 
-	// TODO: 4.0 - work through this list
-	resourceFieldsWhichNeedToBeAddressed := map[string]map[string]struct{}{
-		// 1: Fields which require renaming etc
-		"azurerm_datadog_monitor_sso_configuration": {
-			// should be fixed in 4.0, presumably ditching `_enabled` and adding Enum validation
-			"single_sign_on_enabled": {},
-		},
-		"azurerm_netapp_volume": {
-			// should be fixed in 4.0, presumably ditching `_enabled` and making this `protocols_to_use` or something?
-			"protocols_enabled": {},
-		},
-		"azurerm_kubernetes_cluster": {
-			// this either wants `enabled` removing, or to be marked as a false-positive
-			"transparent_huge_page_enabled": {},
-		},
-		"azurerm_kubernetes_cluster_node_pool": {
-			// this either wants `enabled` removing, or to be marked as a false-positive
-			"transparent_huge_page_enabled": {},
-		},
+func TestDataSourcesDoNotContainANameFieldWithADefaultOfDefault(t *testing.T) {
+	lintDataSources(t, schemalint.NewNameDefaultRule())
+}
 
-		// 2: False Positives
-		"azurerm_iot_security_solution": {
-			// this is a list of recommendations
-			"recommendations_enabled": {},
-		},
-	}
+func TestResourcesDoNotContainANameFieldWithADefaultOfDefault(t *testing.T) {
+	lintResources(t, schemalint.NewNameDefaultRule())
+}
 
-	for _, resourceName := range resourceNames {
-		resource := provider.ResourcesMap[resourceName]
-		fieldsToBeAddressed := resourceFieldsWhichNeedToBeAddressed[resourceName]
+// TestSchemaLintWaiversAreNotStale ensures every entry in waivers.json is
+// still needed - i.e. it still suppresses a real finding. A waiver which
+// matches nothing means the underlying field has since been fixed, renamed
+// or removed, and the waiver should be deleted rather than left behind.
+func TestSchemaLintWaiversAreNotStale(t *testing.T) {
+	provider := TestAzureProvider()
+	waivers := loadSchemaLintWaivers(t)
 
-		if err := schemaContainsEnabledFieldsNotDefinedAsABoolean(resource.Schema, fieldsToBeAddressed); err != nil {
-			t.Fatalf("the Resource %q contains an `_enabled` field which isn't defined as a boolean: %+v", resourceName, err)
-		}
+	findings := schemalint.LintProvider(provider, schemalint.Options{})
+	for _, dead := range schemalint.DeadWaivers(findings, waivers) {
+		t.Errorf("waiver for %q (%s, path %q) owned by %s is no longer needed and should be removed from %s: %s",
+			dead.Resource, dead.RuleID, dead.Path, dead.Owner, schemaLintWaiversPath, dead.Reason)
 	}
 }
 
-func schemaContainsEnabledFieldsNotDefinedAsABoolean(input map[string]*schema.Schema, fieldsToBeAddressed map[string]struct{}) error {
-	// intentionally sorting these so the output is consistent
-	fieldNames := make([]string, 0)
-	for fieldName := range input {
-		fieldNames = append(fieldNames, fieldName)
-	}
-	sort.Strings(fieldNames)
-
-	for _, fieldName := range fieldNames {
-		key := strings.ToLower(fieldName)
-		field := input[fieldName]
+func loadSchemaLintWaivers(t *testing.T) []schemalint.Waiver {
+	t.Helper()
 
-		if strings.HasSuffix(key, "_enabled") {
-			// @tombuildsstuff: we have some Resources which will need to be addressed in the next major version (v4.0)
-			// if this field name matches one we're intentionally ignoring, let's ignore it for now
-			if _, shouldIgnore := fieldsToBeAddressed[key]; shouldIgnore {
-				continue
-			}
-			if field.Type != pluginsdk.TypeBool {
-				return fmt.Errorf("field %q is an `_enabled` field so should be defined as a Boolean but got %+v", fieldName, field.Type)
-			}
-		}
-
-		if field.Type == pluginsdk.TypeList && field.Elem != nil {
-			if val, ok := field.Elem.(*pluginsdk.Resource); ok && val.Schema != nil {
-				if err := schemaContainsEnabledFieldsNotDefinedAsABoolean(val.Schema, fieldsToBeAddressed); err != nil {
-					return fmt.Errorf("the field %q is a List: %+v", fieldName, err)
-				}
-			}
-		}
-
-		if field.Type == pluginsdk.TypeSet && field.Elem != nil {
-			if val, ok := field.Elem.(*pluginsdk.Resource); ok && val.Schema != nil {
-				if err := schemaContainsEnabledFieldsNotDefinedAsABoolean(val.Schema, fieldsToBeAddressed); err != nil {
-					return fmt.Errorf("the field %q is a Set: %+v", fieldName, err)
-				}
-			}
-		}
+	waivers, err := schemalint.LoadWaivers(filepath.Join(".", schemaLintWaiversPath))
+	if err != nil {
+		t.Fatalf("loading %s: %+v", schemaLintWaiversPath, err)
 	}
-
-	return nil
+	return waivers
 }
 
-func TestDataSourcesDoNotContainANameFieldWithADefaultOfDefault(t *testing.T) {
+// lintDataSources runs a single schemalint.Rule against every Data Source
+// registered on the Provider, failing the test for any error-severity finding
+// it reports that isn't covered by a waiver in waivers.json (warnings are
+// logged, see assertNoFindings).
+func lintDataSources(t *testing.T, rule schemalint.Rule) {
+	t.Helper()
 	provider := TestAzureProvider()
+	waivers := loadSchemaLintWaivers(t)
 
-	// intentionally sorting these so the output is consistent
-	dataSourceNames := make([]string, 0)
-	for dataSourceName := range provider.DataSourcesMap {
-		dataSourceNames = append(dataSourceNames, dataSourceName)
-	}
-	sort.Strings(dataSourceNames)
-
-	for _, dataSourceName := range dataSourceNames {
-		dataSource := provider.DataSourcesMap[dataSourceName]
-		if err := schemaContainsANameFieldWithADefaultValueOfDefault(dataSource.Schema, map[string]struct{}{}); err != nil {
-			t.Fatalf("the Data Source %q contains a `name` field with a default value of `default` - this Data Source should be exposed as part of the parent Data Source it's located within: %+v", dataSourceName, err)
-		}
+	for name, dataSource := range provider.DataSourcesMap {
+		findings := rule.Check(name, dataSource.Schema)
+		remaining, _ := schemalint.ApplyWaivers(findings, waivers)
+		assertNoFindings(t, remaining)
 	}
 }
 
-func TestResourcesDoNotContainANameFieldWithADefaultOfDefault(t *testing.T) {
+// lintResources runs a single schemalint.Rule against every Resource
+// registered on the Provider, failing the test for any error-severity finding
+// it reports that isn't covered by a waiver in waivers.json (warnings are
+// logged, see assertNoFindings).
+func lintResources(t *testing.T, rule schemalint.Rule) {
+	t.Helper()
 	provider := TestAzureProvider()
+	waivers := loadSchemaLintWaivers(t)
 
-	// intentionally sorting these so the output is consistent
-	resourceNames := make([]string, 0)
-	for resourceName := range provider.ResourcesMap {
-		resourceNames = append(resourceNames, resourceName)
-	}
-	sort.Strings(resourceNames)
-
-	// TODO: 4.0 - work through this list
-	resourceFieldsWhichNeedToBeAddressed := map[string]map[string]struct{}{
-		// 1: to be addressed in 4.0
-		"azurerm_datadog_monitor_sso_configuration": {
-			// TODO: in 4.0 this resource probably wants embedding within `azurerm_datadog_monitor`
-			// which'll also need the Monitor resource to have Create call Update
-			"name": {},
-		},
-		"azurerm_datadog_monitor_tag_rule": {
-			// TODO: in 4.0 this resource probably wants embedding within `azurerm_datadog_monitor`
-			// which'll also need the Monitor resource to have Create call Update
-			"name": {},
-		},
-
-		// 2. False Positives?
-		"azurerm_redis_enterprise_database": {
-			"name": {},
-		},
-	}
-
-	for _, resourceName := range resourceNames {
-		resource := provider.ResourcesMap[resourceName]
-		fieldsToBeAddressed := resourceFieldsWhichNeedToBeAddressed[resourceName]
-
-		if err := schemaContainsANameFieldWithADefaultValueOfDefault(resource.Schema, fieldsToBeAddressed); err != nil {
-			t.Fatalf("the Resource %q contains a `name` field with a default value of `default` - this Resource should be exposed as part of the parent Resource it's located within: %+v", resourceName, err)
-		}
+	for name, resource := range provider.ResourcesMap {
+		findings := rule.Check(name, resource.Schema)
+		remaining, _ := schemalint.ApplyWaivers(findings, waivers)
+		assertNoFindings(t, remaining)
 	}
 }
 
-func schemaContainsANameFieldWithADefaultValueOfDefault(input map[string]*schema.Schema, fieldsToBeAddressed map[string]struct{}) error {
-	// intentionally sorting these so the output is consistent
-	fieldNames := make([]string, 0)
-	for fieldName := range input {
-		fieldNames = append(fieldNames, fieldName)
-	}
-	sort.Strings(fieldNames)
-
-	for _, fieldName := range fieldNames {
-		key := strings.ToLower(fieldName)
-		field := input[fieldName]
+// assertNoFindings fails the test for any SeverityError finding. Warnings
+// are a heuristic nudge rather than a build-breaking convention, so they're
+// logged instead of failing the test.
+func assertNoFindings(t *testing.T, findings []schemalint.Finding) {
+	t.Helper()
 
-		// @tombuildsstuff: we have some Resources which will need to be addressed in the next major version (v4.0)
-		// if this field name matches one we're intentionally ignoring, let's ignore it for now
-		if _, shouldIgnore := fieldsToBeAddressed[key]; shouldIgnore {
+	for _, finding := range findings {
+		if finding.Severity != schemalint.SeverityError {
+			t.Logf("%q: [%s] %s", finding.Resource, finding.RuleID, finding.Message)
 			continue
 		}
-
-		if strings.EqualFold(key, "name") {
-			var defaultValue any
-			if field.Default != nil {
-				defaultValue = field.Default
-			}
-			if v, ok := defaultValue.(string); ok {
-				if strings.EqualFold(v, "default") {
-					return fmt.Errorf("field %q is a `name` field which contains a default value of `default`", fieldName)
-				}
-			}
-		}
-
-		if field.Type == pluginsdk.TypeList && field.Elem != nil {
-			if val, ok := field.Elem.(*pluginsdk.Resource); ok && val.Schema != nil {
-				if err := schemaContainsANameFieldWithADefaultValueOfDefault(val.Schema, fieldsToBeAddressed); err != nil {
-					return fmt.Errorf("the field %q is a List: %+v", fieldName, err)
-				}
-			}
-		}
-
-		if field.Type == pluginsdk.TypeSet && field.Elem != nil {
-			if val, ok := field.Elem.(*pluginsdk.Resource); ok && val.Schema != nil {
-				if err := schemaContainsANameFieldWithADefaultValueOfDefault(val.Schema, fieldsToBeAddressed); err != nil {
-					return fmt.Errorf("the field %q is a Set: %+v", fieldName, err)
-				}
-			}
-		}
+		t.Fatalf("%q: [%s] %s", finding.Resource, finding.RuleID, finding.Message)
 	}
-
-	return nil
 }
@@ -0,0 +1,75 @@
+package schemalint
+
+import (
+	"sort"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// Options controls which rules are run by Lint/LintProvider.
+type Options struct {
+	// DisabledRuleIDs are Rule IDs which should be skipped, e.g. "AZRM003".
+	DisabledRuleIDs []string
+}
+
+func (o Options) enabledRules() []Rule {
+	disabled := make(map[string]struct{}, len(o.DisabledRuleIDs))
+	for _, id := range o.DisabledRuleIDs {
+		disabled[id] = struct{}{}
+	}
+
+	out := make([]Rule, 0)
+	for _, rule := range AllRules() {
+		if _, skip := disabled[rule.ID()]; skip {
+			continue
+		}
+		out = append(out, rule)
+	}
+	return out
+}
+
+// Lint runs every enabled Rule against a single Resource/Data Source schema.
+func Lint(resourceName string, schema map[string]*pluginsdk.Schema, opts Options) []Finding {
+	findings := make([]Finding, 0)
+	for _, rule := range opts.enabledRules() {
+		findings = append(findings, rule.Check(resourceName, schema)...)
+	}
+	return findings
+}
+
+// LintProvider runs every enabled Rule against every Data Source and
+// Resource registered on the Provider, returning findings sorted by
+// Resource then Path then RuleID so output is stable.
+func LintProvider(provider *pluginsdk.Provider, opts Options) []Finding {
+	findings := make([]Finding, 0)
+
+	dataSourceNames := make([]string, 0, len(provider.DataSourcesMap))
+	for name := range provider.DataSourcesMap {
+		dataSourceNames = append(dataSourceNames, name)
+	}
+	sort.Strings(dataSourceNames)
+	for _, name := range dataSourceNames {
+		findings = append(findings, Lint(name, provider.DataSourcesMap[name].Schema, opts)...)
+	}
+
+	resourceNames := make([]string, 0, len(provider.ResourcesMap))
+	for name := range provider.ResourcesMap {
+		resourceNames = append(resourceNames, name)
+	}
+	sort.Strings(resourceNames)
+	for _, name := range resourceNames {
+		findings = append(findings, Lint(name, provider.ResourcesMap[name].Schema, opts)...)
+	}
+
+	sort.Slice(findings, func(i, j int) bool {
+		if findings[i].Resource != findings[j].Resource {
+			return findings[i].Resource < findings[j].Resource
+		}
+		if findings[i].Path != findings[j].Path {
+			return findings[i].Path < findings[j].Path
+		}
+		return findings[i].RuleID < findings[j].RuleID
+	})
+
+	return findings
+}
@@ -0,0 +1,190 @@
+package schemalint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+// sensitiveIdentifierTokens are substrings in a Go identifier which suggest
+// the value it holds was derived from a secret, used by
+// CheckReadFunctionLeaks to flag a `d.Set("some_endpoint", sensitiveValue)`
+// call where the name `some_endpoint` doesn't itself look sensitive. Only the
+// attribute's name is checked; its schema Sensitive flag is not consulted.
+//
+// This is a heuristic, not a type-level guarantee - it exists to catch the
+// class of bug where a rotated connection string leaks into a non-sensitive
+// `endpoint` field, not to prove the absence of every such leak.
+var sensitiveIdentifierTokens = []string{
+	"connectionstring",
+	"secret",
+	"password",
+	"privatekey",
+	"primarykey",
+	"secondarykey",
+	"accesskey",
+	"sastoken",
+	"bootstraptoken",
+}
+
+// CheckReadFunctionLeaks walks every non-test Go source file under
+// `servicesDir` looking for `<resourceData>.Set("attribute", expr)` calls -
+// where `<resourceData>` is a `*pluginsdk.ResourceData`, not just any type
+// with a `.Set` method - where `expr` references an identifier that looks
+// like it holds a sensitive value, but `attribute` doesn't look sensitive
+// itself.
+func CheckReadFunctionLeaks(servicesDir string) ([]Finding, error) {
+	findings := make([]Finding, 0)
+
+	err := filepath.WalkDir(servicesDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		fileFindings, err := checkFileForSensitiveSetCalls(path)
+		if err != nil {
+			return err
+		}
+		findings = append(findings, fileFindings...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return findings, nil
+}
+
+func checkFileForSensitiveSetCalls(path string) ([]Finding, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceDataIdents := resourceDataParamNames(file)
+
+	findings := make([]Finding, 0)
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "Set" || len(call.Args) != 2 {
+			return true
+		}
+
+		if !looksLikeResourceDataReceiver(sel.X, resourceDataIdents) {
+			return true
+		}
+
+		key, ok := call.Args[0].(*ast.BasicLit)
+		if !ok || key.Kind != token.STRING {
+			return true
+		}
+		attributeName := strings.Trim(key.Value, "\"`")
+		if fieldNameLooksSensitive(strings.ToLower(attributeName)) {
+			return true
+		}
+
+		if ident := sensitiveIdentifierIn(call.Args[1]); ident != "" {
+			position := fset.Position(call.Pos())
+			findings = append(findings, Finding{
+				Resource:     "",
+				Path:         attributeName,
+				RuleID:       "AZRM004b",
+				Severity:     SeverityWarning,
+				Message:      fmt.Sprintf("%s:%d: state attribute %q is set from %q, which looks like it holds a sensitive value", position.Filename, position.Line, attributeName, ident),
+				SuggestedFix: "mark the schema attribute as `Sensitive: true`, or confirm the value isn't actually derived from a secret",
+			})
+		}
+
+		return true
+	})
+
+	return findings, nil
+}
+
+// resourceDataParamNames collects the names of every parameter or field
+// declared with type `*pluginsdk.ResourceData` anywhere in `file` - e.g. the
+// conventional `d *pluginsdk.ResourceData` on an untyped SDK Read function.
+// Used by looksLikeResourceDataReceiver to tell a `d.Set(...)` schema write
+// apart from an unrelated `.Set(...)` method on some other type.
+func resourceDataParamNames(file *ast.File) map[string]struct{} {
+	names := make(map[string]struct{})
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		field, ok := n.(*ast.Field)
+		if !ok || !isResourceDataType(field.Type) {
+			return true
+		}
+		for _, name := range field.Names {
+			names[name.Name] = struct{}{}
+		}
+		return true
+	})
+
+	return names
+}
+
+// isResourceDataType reports whether `expr` is `*pluginsdk.ResourceData` (or
+// `*<anything>.ResourceData`, tolerating an import alias).
+func isResourceDataType(expr ast.Expr) bool {
+	star, ok := expr.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := star.X.(*ast.SelectorExpr)
+	return ok && sel.Sel.Name == "ResourceData"
+}
+
+// looksLikeResourceDataReceiver reports whether `receiver` (the `X` in
+// `receiver.Set(...)`) plausibly refers to a `*pluginsdk.ResourceData` -
+// either a bare identifier declared with that type somewhere in the file
+// (`resourceDataIdents`), or a `<something>.ResourceData` field access such
+// as the typed SDK's `metadata.ResourceData.Set(...)`.
+func looksLikeResourceDataReceiver(receiver ast.Expr, resourceDataIdents map[string]struct{}) bool {
+	switch x := receiver.(type) {
+	case *ast.Ident:
+		_, ok := resourceDataIdents[x.Name]
+		return ok
+	case *ast.SelectorExpr:
+		return x.Sel.Name == "ResourceData"
+	default:
+		return false
+	}
+}
+
+// sensitiveIdentifierIn reports the name of the first identifier within
+// `expr` whose name resembles a sensitive value, if any.
+func sensitiveIdentifierIn(expr ast.Expr) string {
+	var found string
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if found != "" {
+			return false
+		}
+		ident, ok := n.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		name := strings.ToLower(ident.Name)
+		for _, token := range sensitiveIdentifierTokens {
+			if strings.Contains(name, token) {
+				found = ident.Name
+				return false
+			}
+		}
+		return true
+	})
+	return found
+}
@@ -0,0 +1,20 @@
+package schemalint
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// WriteJSON emits `findings` to `w` as a stream of newline-delimited JSON
+// records, one per Finding, so that CI tooling can consume it incrementally
+// (e.g. to post inline PR comments or feed a dashboard) without needing to
+// buffer the whole provider's output.
+func WriteJSON(w io.Writer, findings []Finding) error {
+	enc := json.NewEncoder(w)
+	for _, finding := range findings {
+		if err := enc.Encode(finding); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// Package schemalint contains the schema conventions which are enforced
+// across every Data Source and Resource exposed by this Provider.
+//
+// Historically these checks lived as ad-hoc helper functions inside
+// `internal/provider/provider_schema_test.go` - this package extracts them
+// into a reusable subsystem so that:
+//
+//   - each convention is a self-contained `Rule` with a stable ID
+//   - the same rules can be run from `go test` (CI) and from the
+//     `schemalint` CLI (`make schemalint`), which can emit JSON for
+//     tooling such as inline PR comments or dashboards
+//   - rules can be enabled/disabled individually, and exceptions can be
+//     tracked in a waiver file rather than as TODO comments in a test
+package schemalint
+
+import (
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// Severity indicates how a Finding should be treated by consumers of the
+// schemalint output.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Finding is a single diagnostic raised by a Rule against a specific
+// Resource or Data Source schema. The JSON tags are part of the stable
+// output contract consumed by `schemalint -json` - do not rename them
+// without updating consumers.
+type Finding struct {
+	Resource     string   `json:"resource"`
+	Path         string   `json:"path"`
+	RuleID       string   `json:"rule_id"`
+	Severity     Severity `json:"severity"`
+	Message      string   `json:"message"`
+	SuggestedFix string   `json:"suggested_fix,omitempty"`
+}
+
+// Rule is a single schema convention that can be checked against the
+// schema of a Resource or Data Source.
+type Rule interface {
+	// ID is the stable identifier for this Rule, e.g. "AZRM001".
+	ID() string
+
+	// Severity is the default Severity findings from this Rule are reported at.
+	Severity() Severity
+
+	// Check evaluates the Rule against the given schema and returns a Finding
+	// for every violation found. `resourceName` is the Data Source or
+	// Resource this schema belongs to, used for reporting purposes only.
+	Check(resourceName string, input map[string]*pluginsdk.Schema) []Finding
+}
+
+// AllRules returns every Rule known to schemalint, ordered by ID.
+func AllRules() []Rule {
+	return []Rule{
+		NewSensitiveFieldsRule(),
+		NewSensitiveFieldTypoRule(),
+		NewEnabledBooleanRule(),
+		NewNameDefaultRule(),
+		NewSensitivityPropagationRule(),
+		NewGenconfigSensitiveRule(),
+	}
+}
@@ -0,0 +1,49 @@
+package schemalint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// enabledBooleanRule is AZRM002: fields ending `_enabled` should always be
+// defined as a Boolean.
+type enabledBooleanRule struct{}
+
+// NewEnabledBooleanRule returns the AZRM002 Rule.
+func NewEnabledBooleanRule() Rule {
+	return enabledBooleanRule{}
+}
+
+func (enabledBooleanRule) ID() string {
+	return "AZRM002"
+}
+
+func (enabledBooleanRule) Severity() Severity {
+	return SeverityError
+}
+
+func (r enabledBooleanRule) Check(resourceName string, input map[string]*pluginsdk.Schema) []Finding {
+	findings := make([]Finding, 0)
+
+	walkSchema("", input, func(path string, fieldName string, field *pluginsdk.Schema) {
+		key := strings.ToLower(fieldName)
+		if !strings.HasSuffix(key, "_enabled") {
+			return
+		}
+
+		if field.Type != pluginsdk.TypeBool {
+			findings = append(findings, Finding{
+				Resource:     resourceName,
+				Path:         path,
+				RuleID:       r.ID(),
+				Severity:     r.Severity(),
+				Message:      fmt.Sprintf("field %q is an `_enabled` field so should be defined as a Boolean but got %+v", fieldName, field.Type),
+				SuggestedFix: fmt.Sprintf("change %q to `Type: pluginsdk.TypeBool`", fieldName),
+			})
+		}
+	})
+
+	return findings
+}
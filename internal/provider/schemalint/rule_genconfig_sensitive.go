@@ -0,0 +1,110 @@
+package schemalint
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// genconfigSensitiveRule is AZRM005. `terraform import -generate-config-out`
+// replaces every Sensitive attribute's value with a placeholder so the
+// generated config never contains a secret. That's only possible if the
+// attribute can be omitted from the generated config outright - i.e. it's
+// Optional - or, if it's Required, the resource exposes a write-only `_wo`
+// companion attribute the generated config can populate instead.
+//
+// A Required, Sensitive string with neither is common (e.g.
+// `administrator_login_password` on `azurerm_mssql_server`) and genconfig
+// already has a placeholder path for it - `null # sensitive - value not
+// included by import`. It's a complex (List/Set/Map) attribute in that same
+// shape that has historically crashed genconfig outright, since there's no
+// single scalar to placeholder, so that's the only shape this rule flags.
+type genconfigSensitiveRule struct{}
+
+// NewGenconfigSensitiveRule returns the AZRM005 Rule.
+func NewGenconfigSensitiveRule() Rule {
+	return genconfigSensitiveRule{}
+}
+
+func (genconfigSensitiveRule) ID() string {
+	return "AZRM005"
+}
+
+func (genconfigSensitiveRule) Severity() Severity {
+	return SeverityError
+}
+
+func (r genconfigSensitiveRule) Check(resourceName string, input map[string]*pluginsdk.Schema) []Finding {
+	return r.checkBlock(resourceName, "", input)
+}
+
+func (r genconfigSensitiveRule) checkBlock(resourceName string, path string, input map[string]*pluginsdk.Schema) []Finding {
+	findings := make([]Finding, 0)
+
+	fieldNames := make([]string, 0, len(input))
+	for fieldName := range input {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+
+	for _, fieldName := range fieldNames {
+		field := input[fieldName]
+		fieldPath := fieldName
+		if path != "" {
+			fieldPath = path + "." + fieldName
+		}
+
+		if field.Sensitive && field.Required && r.isComplex(field) && !r.hasWriteOnlyCompanion(fieldName, input) {
+			findings = append(findings, Finding{
+				Resource:     resourceName,
+				Path:         fieldPath,
+				RuleID:       r.ID(),
+				Severity:     r.Severity(),
+				Message:      fmt.Sprintf("field %q is a Required, Sensitive %s with no `%s_wo` companion attribute, so `terraform import -generate-config-out` has no value to placeholder it with and will crash", fieldName, complexTypeName(field), fieldName),
+				SuggestedFix: fmt.Sprintf("make %q Optional, or add a `%s_wo` write-only attribute that import can populate instead", fieldName, fieldName),
+			})
+		}
+
+		if (field.Type == pluginsdk.TypeList || field.Type == pluginsdk.TypeSet) && field.Elem != nil {
+			if nested, ok := field.Elem.(*pluginsdk.Resource); ok && nested.Schema != nil {
+				findings = append(findings, r.checkBlock(resourceName, fieldPath, nested.Schema)...)
+			}
+		}
+	}
+
+	return findings
+}
+
+// isComplex reports whether `field` is a List/Set/Map rather than a scalar -
+// the shape genconfig can't placeholder with a single `null # sensitive`
+// value.
+func (r genconfigSensitiveRule) isComplex(field *pluginsdk.Schema) bool {
+	switch field.Type {
+	case pluginsdk.TypeList, pluginsdk.TypeSet, pluginsdk.TypeMap:
+		return true
+	default:
+		return false
+	}
+}
+
+func complexTypeName(field *pluginsdk.Schema) string {
+	switch field.Type {
+	case pluginsdk.TypeList:
+		return "List"
+	case pluginsdk.TypeSet:
+		return "Set"
+	case pluginsdk.TypeMap:
+		return "Map"
+	default:
+		return "attribute"
+	}
+}
+
+// hasWriteOnlyCompanion reports whether `input` declares a `<fieldName>_wo`
+// attribute alongside `fieldName`, which genconfig can populate in place of
+// the Sensitive attribute itself.
+func (r genconfigSensitiveRule) hasWriteOnlyCompanion(fieldName string, input map[string]*pluginsdk.Schema) bool {
+	_, ok := input[fieldName+"_wo"]
+	return ok
+}
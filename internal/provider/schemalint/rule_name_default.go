@@ -0,0 +1,49 @@
+package schemalint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// nameDefaultRule is AZRM003: a `name` field should never default to the
+// literal string `default` - that's a sign the Resource/Data Source should
+// be exposed as a nested part of its parent instead.
+type nameDefaultRule struct{}
+
+// NewNameDefaultRule returns the AZRM003 Rule.
+func NewNameDefaultRule() Rule {
+	return nameDefaultRule{}
+}
+
+func (nameDefaultRule) ID() string {
+	return "AZRM003"
+}
+
+func (nameDefaultRule) Severity() Severity {
+	return SeverityError
+}
+
+func (r nameDefaultRule) Check(resourceName string, input map[string]*pluginsdk.Schema) []Finding {
+	findings := make([]Finding, 0)
+
+	walkSchema("", input, func(path string, fieldName string, field *pluginsdk.Schema) {
+		if !strings.EqualFold(fieldName, "name") {
+			return
+		}
+
+		if v, ok := field.Default.(string); ok && strings.EqualFold(v, "default") {
+			findings = append(findings, Finding{
+				Resource:     resourceName,
+				Path:         path,
+				RuleID:       r.ID(),
+				Severity:     r.Severity(),
+				Message:      fmt.Sprintf("field %q is a `name` field which contains a default value of `default`", fieldName),
+				SuggestedFix: "expose this as part of the parent Resource/Data Source instead of defaulting `name`",
+			})
+		}
+	})
+
+	return findings
+}
@@ -0,0 +1,119 @@
+package schemalint
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// sensitiveFieldsRule is AZRM001: fields whose name implies they hold a
+// secret value must be marked as Sensitive.
+type sensitiveFieldsRule struct{}
+
+// NewSensitiveFieldsRule returns the AZRM001 Rule.
+func NewSensitiveFieldsRule() Rule {
+	return sensitiveFieldsRule{}
+}
+
+func (sensitiveFieldsRule) ID() string {
+	return "AZRM001"
+}
+
+func (sensitiveFieldsRule) Severity() Severity {
+	return SeverityError
+}
+
+// exactMatchSensitiveFieldNames are field names which are always sensitive,
+// regardless of what Resource/Data Source they're found on. This is also
+// the canonical list AZRM001b typo-checks other field names against.
+var exactMatchSensitiveFieldNames = []string{
+	"api_key",
+	"api_secret_key",
+	"password",
+	"private_key",
+	"ssh_private_key",
+}
+
+// sensitiveFieldNamePatterns covers common shapes of Azure secret field
+// names which aren't worth enumerating exactly, e.g. `storage_account_primary_key`
+// or `listen_key_primary_connection_string`.
+var sensitiveFieldNamePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`.*(access|primary|secondary)_key$`),
+	regexp.MustCompile(`.*_secret(_value)?$`),
+	regexp.MustCompile(`.*(sas|shared_access)_.*token$`),
+	regexp.MustCompile(`.*connection_string$`),
+	regexp.MustCompile(`.*_password$`),
+	regexp.MustCompile(`.*_api_key$`),
+	regexp.MustCompile(`^client_certificate$`),
+	regexp.MustCompile(`^certificate_password$`),
+	regexp.MustCompile(`^bootstrap_token$`),
+	regexp.MustCompile(`.*_pat$`),
+}
+
+func (r sensitiveFieldsRule) Check(resourceName string, input map[string]*pluginsdk.Schema) []Finding {
+	findings := make([]Finding, 0)
+
+	walkSchema("", input, func(path string, fieldName string, field *pluginsdk.Schema) {
+		key := strings.ToLower(fieldName)
+
+		if !fieldNameLooksSensitive(key) {
+			return
+		}
+
+		if !field.Sensitive {
+			findings = append(findings, r.finding(resourceName, path, fieldName))
+			return
+		}
+
+		if field.Type == pluginsdk.TypeString && descriptionLeaksExampleValue(field.Description) {
+			findings = append(findings, Finding{
+				Resource:     resourceName,
+				Path:         path,
+				RuleID:       r.ID(),
+				Severity:     r.Severity(),
+				Message:      fmt.Sprintf("field %q is Sensitive but its Description appears to include an example value", fieldName),
+				SuggestedFix: fmt.Sprintf("remove the example value from %q's Description", fieldName),
+			})
+		}
+	})
+
+	return findings
+}
+
+func fieldNameLooksSensitive(key string) bool {
+	for _, val := range exactMatchSensitiveFieldNames {
+		if key == val {
+			return true
+		}
+	}
+
+	for _, pattern := range sensitiveFieldNamePatterns {
+		if pattern.MatchString(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// descriptionLeaksExampleValue is a heuristic for a Description which
+// includes a literal example of the field's own sensitive value, e.g.
+// "...e.g. `Sup3rS3cr3t!`" - these end up baked into the provider docs.
+var descriptionExamplePattern = regexp.MustCompile("(?i)(e\\.g\\.?|for example)[^`]*`[^`]{8,}`")
+
+func descriptionLeaksExampleValue(description string) bool {
+	return descriptionExamplePattern.MatchString(description)
+}
+
+func (r sensitiveFieldsRule) finding(resourceName, path, fieldName string) Finding {
+	return Finding{
+		Resource:     resourceName,
+		Path:         path,
+		RuleID:       r.ID(),
+		Severity:     r.Severity(),
+		Message:      fmt.Sprintf("field %q is a sensitive value and should be marked as Sensitive", fieldName),
+		SuggestedFix: fmt.Sprintf("set `Sensitive: true` on %q", fieldName),
+	}
+}
@@ -0,0 +1,59 @@
+package schemalint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// sensitiveFieldTypoRule is AZRM001b: catches a field name which is a
+// near-miss (edit distance 1) of one of the exact-match sensitive names, e.g.
+// `passwrd` or `privte_key`, which AZRM001's exact/pattern matching can't see
+// because the name itself doesn't match anything. Near-misses of the regex
+// patterns alone are not checked.
+type sensitiveFieldTypoRule struct {
+	maxDistance int
+}
+
+// NewSensitiveFieldTypoRule returns the AZRM001b Rule.
+func NewSensitiveFieldTypoRule() Rule {
+	return sensitiveFieldTypoRule{maxDistance: 1}
+}
+
+func (sensitiveFieldTypoRule) ID() string {
+	return "AZRM001b"
+}
+
+func (sensitiveFieldTypoRule) Severity() Severity {
+	return SeverityWarning
+}
+
+func (r sensitiveFieldTypoRule) Check(resourceName string, input map[string]*pluginsdk.Schema) []Finding {
+	findings := make([]Finding, 0)
+
+	walkSchema("", input, func(path string, fieldName string, field *pluginsdk.Schema) {
+		key := strings.ToLower(fieldName)
+
+		// an exact/pattern match is AZRM001's job, not a typo
+		if fieldNameLooksSensitive(key) {
+			return
+		}
+
+		for _, canonical := range exactMatchSensitiveFieldNames {
+			if distance := levenshteinDistance(key, canonical); distance > 0 && distance <= r.maxDistance {
+				findings = append(findings, Finding{
+					Resource:     resourceName,
+					Path:         path,
+					RuleID:       r.ID(),
+					Severity:     r.Severity(),
+					Message:      fmt.Sprintf("field %q closely resembles the sensitive field name %q - likely a typo", fieldName, canonical),
+					SuggestedFix: fmt.Sprintf("rename %q to %q, or if intentional add a schemalint waiver", fieldName, canonical),
+				})
+				return
+			}
+		}
+	})
+
+	return findings
+}
@@ -0,0 +1,166 @@
+package schemalint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// sensitivityPropagationRule is AZRM004. Azure APIs frequently return a
+// Computed attribute alongside a Sensitive one it's derived from - e.g. a
+// resource exposes both `primary_access_key` (Sensitive) and a Computed
+// `primary_access_key_version` that isn't marked Sensitive. If the Computed
+// sibling isn't also marked Sensitive, the same secret ends up readable in
+// plan/state output under a different name.
+type sensitivityPropagationRule struct{}
+
+// NewSensitivityPropagationRule returns the AZRM004 Rule.
+func NewSensitivityPropagationRule() Rule {
+	return sensitivityPropagationRule{}
+}
+
+func (sensitivityPropagationRule) ID() string {
+	return "AZRM004"
+}
+
+func (sensitivityPropagationRule) Severity() Severity {
+	return SeverityWarning
+}
+
+// sensitivityPropagationStopTokens are tokens too generic to imply a
+// relationship on their own, e.g. `the_primary_id` and `the_primary_key`
+// shouldn't match purely because they share `the`, and `primary_blob_endpoint`
+// shouldn't match `primary_access_key` purely because they share `primary`.
+var sensitivityPropagationStopTokens = map[string]struct{}{
+	"the":       {},
+	"a":         {},
+	"an":        {},
+	"of":        {},
+	"and":       {},
+	"id":        {},
+	"name":      {},
+	"type":      {},
+	"enabled":   {},
+	"value":     {},
+	"primary":   {},
+	"secondary": {},
+	"access":    {},
+	"blob":      {},
+	"endpoint":  {},
+	"host":      {},
+}
+
+// sensitivityPropagationSecretTypeTokens are tokens which themselves imply a
+// secret value, as opposed to tokens like `primary`/`blob`/`endpoint` which
+// merely describe which of several related fields one is. A shared token
+// only counts as a sign of propagation when it's one of these - otherwise
+// `primary_connection_string` (Sensitive) and `primary_blob_endpoint`
+// (Computed) would match purely for sharing `primary`.
+var sensitivityPropagationSecretTypeTokens = map[string]struct{}{
+	"key":         {},
+	"password":    {},
+	"secret":      {},
+	"token":       {},
+	"certificate": {},
+	"pat":         {},
+	"connection":  {},
+	"string":      {},
+}
+
+func (r sensitivityPropagationRule) Check(resourceName string, input map[string]*pluginsdk.Schema) []Finding {
+	return r.checkBlock(resourceName, "", input)
+}
+
+func (r sensitivityPropagationRule) checkBlock(resourceName string, path string, input map[string]*pluginsdk.Schema) []Finding {
+	findings := make([]Finding, 0)
+
+	sensitiveTokens := make(map[string][]string) // fieldName -> tokens
+	for fieldName, field := range input {
+		if field.Sensitive {
+			sensitiveTokens[fieldName] = tokenize(fieldName)
+		}
+	}
+
+	fieldNames := make([]string, 0, len(input))
+	for fieldName := range input {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+
+	for _, fieldName := range fieldNames {
+		field := input[fieldName]
+		fieldPath := fieldName
+		if path != "" {
+			fieldPath = path + "." + fieldName
+		}
+
+		if field.Computed && !field.Sensitive {
+			if sibling, ok := r.sensitiveSibling(fieldName, sensitiveTokens); ok {
+				findings = append(findings, Finding{
+					Resource:     resourceName,
+					Path:         fieldPath,
+					RuleID:       r.ID(),
+					Severity:     r.Severity(),
+					Message:      fmt.Sprintf("field %q is Computed and shares a name with the Sensitive field %q, but isn't itself marked Sensitive", fieldName, sibling),
+					SuggestedFix: fmt.Sprintf("mark %q as `Sensitive: true`, or rename it so it no longer resembles %q", fieldName, sibling),
+				})
+			}
+		}
+
+		if (field.Type == pluginsdk.TypeList || field.Type == pluginsdk.TypeSet) && field.Elem != nil {
+			if nested, ok := field.Elem.(*pluginsdk.Resource); ok && nested.Schema != nil {
+				findings = append(findings, r.checkBlock(resourceName, fieldPath, nested.Schema)...)
+			}
+		}
+	}
+
+	return findings
+}
+
+// sensitiveSibling returns the name of a Sensitive field in the same block
+// which shares a meaningful token with `fieldName`, if any.
+func (r sensitivityPropagationRule) sensitiveSibling(fieldName string, sensitiveTokens map[string][]string) (string, bool) {
+	tokens := tokenize(fieldName)
+
+	// keep candidates in a stable order so output doesn't flap between runs
+	candidates := make([]string, 0, len(sensitiveTokens))
+	for name := range sensitiveTokens {
+		candidates = append(candidates, name)
+	}
+	sort.Strings(candidates)
+
+	for _, sensitiveName := range candidates {
+		if sensitiveName == fieldName {
+			continue
+		}
+		for _, token := range tokens {
+			if _, stop := sensitivityPropagationStopTokens[token]; stop {
+				continue
+			}
+			if _, secretType := sensitivityPropagationSecretTypeTokens[token]; !secretType {
+				continue
+			}
+			for _, sensitiveToken := range sensitiveTokens[sensitiveName] {
+				if token == sensitiveToken {
+					return sensitiveName, true
+				}
+			}
+		}
+	}
+
+	return "", false
+}
+
+func tokenize(fieldName string) []string {
+	lower := strings.ToLower(fieldName)
+	parts := strings.Split(lower, "_")
+	tokens := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if len(part) > 2 {
+			tokens = append(tokens, part)
+		}
+	}
+	return tokens
+}
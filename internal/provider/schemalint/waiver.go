@@ -0,0 +1,178 @@
+package schemalint
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// neverExpires is a sentinel `Until` value for waivers which track a
+// permanent false-positive rather than debt to be paid off by a given
+// release, e.g. `recommendations_enabled` being a list of recommendations
+// rather than a feature toggle.
+const neverExpires = "unreleased"
+
+// Waiver records a single, checked-in exception to a Rule - replacing the
+// `resourceFieldsWhichNeedToBeAddressed` maps that used to live inline in
+// `provider_schema_test.go`. Keeping these structured (rather than as a
+// `map[string]struct{}` with a TODO comment) lets the lint runner tell a
+// waiver that's gone stale apart from one that's still needed, and lets a
+// release build fail once `Until` has passed.
+type Waiver struct {
+	// Resource is the Data Source or Resource type this waiver applies to, e.g. `azurerm_netapp_volume`.
+	Resource string `json:"resource"`
+
+	// RuleID is the Rule this waiver suppresses, e.g. `AZRM002`.
+	RuleID string `json:"rule_id"`
+
+	// Path is the dotted schema path the waiver applies to, e.g. `protocols_enabled`
+	// or `login.password` for a field nested in a block - walkSchema's paths are
+	// index-less, so a single waiver applies to the field at every index of a
+	// List/Set. An empty Path waives every finding the Rule raises for Resource.
+	Path string `json:"path,omitempty"`
+
+	// Until is the Provider version by which this waiver must be resolved,
+	// e.g. `4.0`, or the sentinel `unreleased` for waivers tracking a
+	// permanent false-positive rather than debt.
+	Until string `json:"until"`
+
+	Reason string `json:"reason"`
+	Owner  string `json:"owner"`
+}
+
+func (w Waiver) matches(f Finding) bool {
+	if w.Resource != f.Resource || w.RuleID != f.RuleID {
+		return false
+	}
+	if w.Path == "" {
+		return true
+	}
+	return strings.EqualFold(w.Path, f.Path)
+}
+
+// LoadWaivers reads the checked-in waiver file at `path` (see waivers.json
+// alongside this package for the live set).
+func LoadWaivers(path string) ([]Waiver, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading waivers file %q: %+v", path, err)
+	}
+
+	var waivers []Waiver
+	if err := json.Unmarshal(data, &waivers); err != nil {
+		return nil, fmt.Errorf("parsing waivers file %q: %+v", path, err)
+	}
+
+	return waivers, nil
+}
+
+// ApplyWaivers splits `findings` into those which remain after waivers are
+// applied and those which were suppressed by a waiver.
+func ApplyWaivers(findings []Finding, waivers []Waiver) (remaining []Finding, waived []Finding) {
+	for _, finding := range findings {
+		suppressed := false
+		for _, waiver := range waivers {
+			if waiver.matches(finding) {
+				suppressed = true
+				break
+			}
+		}
+		if suppressed {
+			waived = append(waived, finding)
+		} else {
+			remaining = append(remaining, finding)
+		}
+	}
+	return remaining, waived
+}
+
+// DeadWaivers returns every waiver in `waivers` which no longer matches any
+// finding in `findings` - meaning the underlying field has since been fixed
+// (or renamed/removed) and the waiver should be deleted.
+func DeadWaivers(findings []Finding, waivers []Waiver) []Waiver {
+	dead := make([]Waiver, 0)
+	for _, waiver := range waivers {
+		stillNeeded := false
+		for _, finding := range findings {
+			if waiver.matches(finding) {
+				stillNeeded = true
+				break
+			}
+		}
+		if !stillNeeded {
+			dead = append(dead, waiver)
+		}
+	}
+	return dead
+}
+
+// ExpiredWaivers returns every waiver whose `Until` version has been reached
+// or passed by `currentVersion` and which still suppresses a live finding in
+// `findings` - a waiver that's both expired and no longer needed is reported
+// once, by DeadWaivers, rather than twice. `currentVersion` and `Until` are
+// both of the form `MAJOR.MINOR`; the sentinel `unreleased` never expires.
+func ExpiredWaivers(waivers []Waiver, findings []Finding, currentVersion string) ([]Waiver, error) {
+	expired := make([]Waiver, 0)
+	for _, waiver := range waivers {
+		if waiver.Until == neverExpires {
+			continue
+		}
+
+		cmp, err := compareVersions(waiver.Until, currentVersion)
+		if err != nil {
+			return nil, fmt.Errorf("waiver for %q (%s): %+v", waiver.Resource, waiver.RuleID, err)
+		}
+		if cmp > 0 {
+			continue
+		}
+
+		stillNeeded := false
+		for _, finding := range findings {
+			if waiver.matches(finding) {
+				stillNeeded = true
+				break
+			}
+		}
+		if stillNeeded {
+			expired = append(expired, waiver)
+		}
+	}
+	return expired, nil
+}
+
+// compareVersions compares two `MAJOR.MINOR` version strings, returning a
+// negative number if a < b, 0 if equal, and a positive number if a > b.
+func compareVersions(a, b string) (int, error) {
+	aMajor, aMinor, err := splitVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	bMajor, bMinor, err := splitVersion(b)
+	if err != nil {
+		return 0, err
+	}
+
+	if aMajor != bMajor {
+		return aMajor - bMajor, nil
+	}
+	return aMinor - bMinor, nil
+}
+
+func splitVersion(v string) (major int, minor int, err error) {
+	parts := strings.SplitN(v, ".", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("version %q is not of the form MAJOR.MINOR", v)
+	}
+
+	major, err = strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("version %q has a non-numeric major component: %+v", v, err)
+	}
+	minor, err = strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("version %q has a non-numeric minor component: %+v", v, err)
+	}
+	return major, minor, nil
+}
@@ -0,0 +1,53 @@
+package schemalint
+
+import (
+	"sort"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// walkFunc is invoked once for every field in a schema, including fields
+// nested within List/Set/Map blocks, and a second time, at the same path, for
+// a TypeMap field's element schema. `path` is a dotted, index-less path from
+// the root of the schema, e.g. `login.password`.
+type walkFunc func(path string, fieldName string, field *pluginsdk.Schema)
+
+// walkSchema visits every field in `input`, recursing into nested List/Set/Map
+// block schemas, in a deterministic (sorted) order so output is stable.
+func walkSchema(path string, input map[string]*pluginsdk.Schema, visit walkFunc) {
+	fieldNames := make([]string, 0, len(input))
+	for fieldName := range input {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+
+	for _, fieldName := range fieldNames {
+		field := input[fieldName]
+		fieldPath := fieldName
+		if path != "" {
+			fieldPath = path + "." + fieldName
+		}
+
+		visit(fieldPath, fieldName, field)
+
+		if (field.Type == pluginsdk.TypeList || field.Type == pluginsdk.TypeSet || field.Type == pluginsdk.TypeMap) && field.Elem != nil {
+			if nested, ok := field.Elem.(*pluginsdk.Resource); ok && nested.Schema != nil {
+				walkSchema(fieldPath, nested.Schema, visit)
+			}
+
+			// Only a TypeMap's Elem is worth a second visit - it's the schema
+			// shared by every value in the map, e.g. `map[string]string` is
+			// `*pluginsdk.Schema{Type: TypeString}`, and that schema's own
+			// Sensitive/Description are independent of the map field's. A
+			// List/Set of scalars has no such independent element schema to
+			// check - re-visiting it would just re-test the parent field's own
+			// name against the *element's* (always-false) Sensitive flag,
+			// producing false positives and a duplicate finding at the same path.
+			if field.Type == pluginsdk.TypeMap {
+				if elemSchema, ok := field.Elem.(*pluginsdk.Schema); ok {
+					visit(fieldPath, fieldName, elemSchema)
+				}
+			}
+		}
+	}
+}
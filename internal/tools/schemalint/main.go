@@ -0,0 +1,93 @@
+// schemalint is a standalone CLI wrapping the `internal/provider/schemalint`
+// subsystem so the provider's schema conventions can be audited outside of
+// `go test`, e.g. to post inline PR comments or feed a dashboard.
+//
+// Usage:
+//
+//	go run ./internal/tools/schemalint [-json] [-disable AZRM003,AZRM002]
+//
+// or via `make schemalint`.
+//
+// Pass `-release-version` (e.g. `-release-version 4.0`) in a release build
+// to additionally fail when a waiver's `until` version has been reached.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider/schemalint"
+)
+
+func main() {
+	jsonOutput := flag.Bool("json", false, "emit findings as newline-delimited JSON instead of human-readable text")
+	disableRules := flag.String("disable", "", "comma-separated list of Rule IDs to skip, e.g. AZRM003")
+	waiversPath := flag.String("waivers", "internal/provider/schemalint/waivers.json", "path to the waivers file")
+	releaseVersion := flag.String("release-version", "", "if set, fail when a waiver's `until` version has been reached by this version")
+	servicesDir := flag.String("services-dir", "internal/services", "directory to scan for Read functions which may leak sensitive values (advisory, never fails the build)")
+	flag.Parse()
+
+	opts := schemalint.Options{}
+	if *disableRules != "" {
+		opts.DisabledRuleIDs = strings.Split(*disableRules, ",")
+	}
+
+	waivers, err := schemalint.LoadWaivers(*waiversPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%+v\n", err)
+		os.Exit(1)
+	}
+
+	prov := provider.TestAzureProvider()
+	allFindings := schemalint.LintProvider(prov, opts)
+	findings, _ := schemalint.ApplyWaivers(allFindings, waivers)
+
+	if *jsonOutput {
+		if err := schemalint.WriteJSON(os.Stdout, findings); err != nil {
+			fmt.Fprintf(os.Stderr, "writing JSON output: %+v\n", err)
+			os.Exit(1)
+		}
+	} else {
+		for _, finding := range findings {
+			fmt.Printf("%s: [%s] %s %s: %s\n", finding.Severity, finding.RuleID, finding.Resource, finding.Path, finding.Message)
+		}
+	}
+
+	exitCode := 0
+	for _, finding := range findings {
+		if finding.Severity == schemalint.SeverityError {
+			exitCode = 1
+			break
+		}
+	}
+
+	for _, dead := range schemalint.DeadWaivers(allFindings, waivers) {
+		fmt.Fprintf(os.Stderr, "dead waiver: %q (%s, path %q) owned by %s is no longer needed\n", dead.Resource, dead.RuleID, dead.Path, dead.Owner)
+		exitCode = 1
+	}
+
+	if leaks, err := schemalint.CheckReadFunctionLeaks(*servicesDir); err != nil {
+		fmt.Fprintf(os.Stderr, "checking Read functions for sensitive leaks: %+v\n", err)
+	} else {
+		for _, finding := range leaks {
+			fmt.Fprintf(os.Stderr, "advisory: [%s] %s\n", finding.RuleID, finding.Message)
+		}
+	}
+
+	if *releaseVersion != "" {
+		expired, err := schemalint.ExpiredWaivers(waivers, allFindings, *releaseVersion)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%+v\n", err)
+			os.Exit(1)
+		}
+		for _, waiver := range expired {
+			fmt.Fprintf(os.Stderr, "expired waiver: %q (%s, path %q) was due by %s, owned by %s: %s\n", waiver.Resource, waiver.RuleID, waiver.Path, waiver.Until, waiver.Owner, waiver.Reason)
+			exitCode = 1
+		}
+	}
+
+	os.Exit(exitCode)
+}